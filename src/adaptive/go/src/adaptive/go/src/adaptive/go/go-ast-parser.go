@@ -1,34 +1,69 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"go/ast"
+	"go/importer"
 	"go/parser"
+	"go/printer"
 	"go/token"
+	"go/types"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
 )
 
 type FieldInfo struct {
-	Name      string `json:"name"`
-	Type      string `json:"type"`
-	Tag       string `json:"tag,omitempty"`
-	Exported  bool   `json:"exported"`
-	IsPointer bool   `json:"isPointer"`
-	IsSlice   bool   `json:"isSlice"`
-	IsMap     bool   `json:"isMap"`
-	IsChan    bool   `json:"isChan"`
-	IsGeneric bool   `json:"isGeneric"`
+	Name       string              `json:"name"`
+	Type       string              `json:"type"`
+	Tag        string              `json:"tag,omitempty"`
+	ParsedTag  map[string]TagValue `json:"parsedTag,omitempty"`
+	Exported   bool                `json:"exported"`
+	IsPointer  bool                `json:"isPointer"`
+	IsSlice    bool                `json:"isSlice"`
+	IsMap      bool                `json:"isMap"`
+	IsChan     bool                `json:"isChan"`
+	IsGeneric  bool                `json:"isGeneric"`
+	TypeArgs   []string            `json:"typeArgs,omitempty"`
+	OmitEmpty  bool                `json:"omitEmpty,omitempty"`
+	JSONName   string              `json:"jsonName,omitempty"`
+	JSONIgnore bool                `json:"jsonIgnore,omitempty"`
+	DBColumn   string              `json:"dbColumn,omitempty"`
+	Doc        string              `json:"doc,omitempty"`
+}
+
+// TagValue is one key's parsed struct tag, e.g. `json:"name,omitempty"` becomes
+// {Name: "name", Options: ["omitempty"], Raw: "name,omitempty"}.
+type TagValue struct {
+	Name    string   `json:"name"`
+	Options []string `json:"options,omitempty"`
+	Raw     string   `json:"raw"`
 }
 
 type MethodInfo struct {
-	Name       string      `json:"name"`
-	Parameters []ParamInfo `json:"parameters"`
-	ReturnType string      `json:"returnType"`
-	Exported   bool        `json:"exported"`
-	IsGeneric  bool        `json:"isGeneric"`
+	Name       string          `json:"name"`
+	Parameters []ParamInfo     `json:"parameters"`
+	ReturnType string          `json:"returnType"`
+	Exported   bool            `json:"exported"`
+	IsGeneric  bool            `json:"isGeneric"`
+	TypeParams []TypeParamInfo `json:"typeParams,omitempty"`
+	Doc        string          `json:"doc,omitempty"`
+}
+
+// TypeParamInfo describes one type parameter of a generic struct, interface or
+// function declaration. ConstraintTerms is only populated when the constraint
+// is a union (e.g. `~int | ~float64`) or a lone approximation element (`~int`);
+// each term keeps its `~` approximation flag so callers can tell an
+// underlying-type constraint from an exact one.
+type TypeParamInfo struct {
+	Name            string   `json:"name"`
+	Constraint      string   `json:"constraint"`
+	ConstraintTerms []string `json:"constraintTerms,omitempty"`
 }
 
 type ParamInfo struct {
@@ -42,39 +77,72 @@ type ReceiverInfo struct {
 }
 
 type StructInfo struct {
-	Name    string      `json:"name"`
-	Type    string      `json:"type"`
-	Fields  []FieldInfo `json:"fields"`
-	Methods []MethodInfo `json:"methods"`
-	Embeds  []EmbedInfo `json:"embeds"`
+	Name       string          `json:"name"`
+	Type       string          `json:"type"`
+	Fields     []FieldInfo     `json:"fields"`
+	Methods    []MethodInfo    `json:"methods"`
+	Embeds     []EmbedInfo     `json:"embeds"`
+	TypeParams []TypeParamInfo `json:"typeParams,omitempty"`
+	File       string          `json:"file,omitempty"`
+	Line       int             `json:"line,omitempty"`
+	Doc        string          `json:"doc,omitempty"`
 }
 
 type InterfaceInfo struct {
-	Name    string       `json:"name"`
-	Type    string       `json:"type"`
-	Methods []MethodInfo `json:"methods"`
-	Embeds  []string     `json:"embeds"`
+	Name       string          `json:"name"`
+	Type       string          `json:"type"`
+	Methods    []MethodInfo    `json:"methods"`
+	Embeds     []string        `json:"embeds"`
+	TypeParams []TypeParamInfo `json:"typeParams,omitempty"`
+	File       string          `json:"file,omitempty"`
+	Line       int             `json:"line,omitempty"`
+	Doc        string          `json:"doc,omitempty"`
 }
 
 type FunctionInfo struct {
-	Name       string      `json:"name"`
-	Parameters []ParamInfo `json:"parameters"`
-	ReturnType string      `json:"returnType"`
-	Exported   bool        `json:"exported"`
+	Name       string          `json:"name"`
+	Parameters []ParamInfo     `json:"parameters"`
+	ReturnType string          `json:"returnType"`
+	Exported   bool            `json:"exported"`
+	TypeParams []TypeParamInfo `json:"typeParams,omitempty"`
+	File       string          `json:"file,omitempty"`
+	Line       int             `json:"line,omitempty"`
+	Doc        string          `json:"doc,omitempty"`
+	Calls      []CallSite      `json:"calls,omitempty"`
 }
 
 type MethodWithReceiver struct {
-	Name       string       `json:"name"`
-	Receiver   ReceiverInfo `json:"receiver"`
-	Parameters []ParamInfo  `json:"parameters"`
-	ReturnType string       `json:"returnType"`
-	Exported   bool         `json:"exported"`
+	Name         string       `json:"name"`
+	Receiver     ReceiverInfo `json:"receiver"`
+	Parameters   []ParamInfo  `json:"parameters"`
+	ReturnType   string       `json:"returnType"`
+	Exported     bool         `json:"exported"`
+	File         string       `json:"file,omitempty"`
+	Line         int          `json:"line,omitempty"`
+	Doc          string       `json:"doc,omitempty"`
+	Calls        []CallSite   `json:"calls,omitempty"`
+	UsesGlobals  []string     `json:"usesGlobals,omitempty"`
+	ReadsFields  []string     `json:"readsFields,omitempty"`
+	WritesFields []string     `json:"writesFields,omitempty"`
+}
+
+// CallSite describes one function or method call made from within a
+// FunctionInfo or MethodWithReceiver body. Package and IsInterface are
+// only resolved in whole-package mode, where go/types can tell a concrete
+// method call from a call through an interface value.
+type CallSite struct {
+	Callee      string `json:"callee"`
+	Package     string `json:"package,omitempty"`
+	Receiver    string `json:"receiver,omitempty"`
+	IsInterface bool   `json:"isInterface,omitempty"`
+	Pos         string `json:"pos,omitempty"`
 }
 
 type EmbedInfo struct {
-	Type      string `json:"type"`
-	IsPointer bool   `json:"isPointer"`
-	IsGeneric bool   `json:"isGeneric"`
+	Type      string   `json:"type"`
+	IsPointer bool     `json:"isPointer"`
+	IsGeneric bool     `json:"isGeneric"`
+	TypeArgs  []string `json:"typeArgs,omitempty"`
 }
 
 type ConstInfo struct {
@@ -82,6 +150,7 @@ type ConstInfo struct {
 	Type     string `json:"type,omitempty"`
 	Value    string `json:"value,omitempty"`
 	Exported bool   `json:"exported"`
+	Doc      string `json:"doc,omitempty"`
 }
 
 type VarInfo struct {
@@ -89,6 +158,7 @@ type VarInfo struct {
 	Type     string `json:"type,omitempty"`
 	Value    string `json:"value,omitempty"`
 	Exported bool   `json:"exported"`
+	Doc      string `json:"doc,omitempty"`
 }
 
 type ImportInfo struct {
@@ -98,17 +168,30 @@ type ImportInfo struct {
 }
 
 type GoMetadata struct {
-	Path        string                `json:"path"`
-	PackageName string                `json:"packageName"`
-	Imports     []ImportInfo          `json:"imports"`
-	Structs     []StructInfo          `json:"structs"`
-	Interfaces  []InterfaceInfo       `json:"interfaces"`
-	Functions   []FunctionInfo        `json:"functions"`
-	Methods     []MethodWithReceiver  `json:"methods"`
-	Types       []TypeInfo            `json:"types"`
-	Constants   []ConstInfo           `json:"constants"`
-	Variables   []VarInfo             `json:"variables"`
-	Errors      []string              `json:"errors"`
+	Path            string               `json:"path"`
+	PackageName     string               `json:"packageName"`
+	Files           []string             `json:"files,omitempty"`
+	Imports         []ImportInfo         `json:"imports"`
+	Structs         []StructInfo         `json:"structs"`
+	Interfaces      []InterfaceInfo      `json:"interfaces"`
+	Functions       []FunctionInfo       `json:"functions"`
+	Methods         []MethodWithReceiver `json:"methods"`
+	Types           []TypeInfo           `json:"types"`
+	Constants       []ConstInfo          `json:"constants"`
+	Variables       []VarInfo            `json:"variables"`
+	Implementations []ImplementationInfo `json:"implementations,omitempty"`
+	Examples        []ExampleInfo        `json:"examples,omitempty"`
+	Errors          []string             `json:"errors"`
+}
+
+// ExampleInfo is a top-level ExampleXxx function found in a _test.go file in the
+// same directory, following the go/doc convention of naming: ExampleFoo documents
+// Foo, ExampleFoo_Bar documents Foo.Bar, and a bare Example documents the package.
+type ExampleInfo struct {
+	Name   string `json:"name"`
+	Target string `json:"target,omitempty"`
+	Code   string `json:"code"`
+	Output string `json:"output,omitempty"`
 }
 
 type TypeInfo struct {
@@ -117,16 +200,37 @@ type TypeInfo struct {
 	UnderlyingType string `json:"underlyingType"`
 }
 
+// ImplementationInfo links an interface to a concrete type that satisfies it, so
+// the adaptive-tests scaffolder can find the real implementation behind an
+// interface dependency instead of only seeing the interface's method set.
+type ImplementationInfo struct {
+	Interface    string   `json:"interface"`
+	Type         string   `json:"type"`
+	ReceiverKind string   `json:"receiverKind"` // "value" or "pointer"
+	Methods      []string `json:"methods"`
+}
+
 func main() {
-	if len(os.Args) < 2 {
-		fmt.Fprintf(os.Stderr, "Usage: %s <go-file>\n", os.Args[0])
+	packageMode := flag.Bool("package", false, "treat the argument as a directory and type-check the whole package instead of a single file")
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 1 {
+		fmt.Fprintf(os.Stderr, "Usage: %s [-package] <go-file-or-dir>\n", os.Args[0])
 		os.Exit(1)
 	}
 
-	filePath := os.Args[1]
-	metadata, err := parseGoFile(filePath)
+	path := args[0]
+
+	var metadata *GoMetadata
+	var err error
+	if *packageMode {
+		metadata, err = parseGoPackage(path)
+	} else {
+		metadata, err = parseGoFile(path)
+	}
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error parsing Go file: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error parsing Go source: %v\n", err)
 		os.Exit(1)
 	}
 
@@ -177,30 +281,191 @@ func parseGoFile(filePath string) (*GoMetadata, error) {
 	// Walk the AST
 	ast.Inspect(node, func(n ast.Node) bool {
 		switch x := n.(type) {
-		case *ast.TypeSpec:
-			handleTypeSpec(x, metadata)
-		case *ast.FuncDecl:
-			handleFuncDecl(x, metadata)
 		case *ast.GenDecl:
+			if x.Tok == token.TYPE {
+				declDoc := ""
+				if len(x.Specs) == 1 {
+					declDoc = commentText(x.Doc)
+				}
+				for _, spec := range x.Specs {
+					if ts, ok := spec.(*ast.TypeSpec); ok {
+						handleTypeSpec(ts, metadata, declDoc)
+					}
+				}
+				return false
+			}
 			handleGenDecl(x, metadata)
+		case *ast.FuncDecl:
+			handleFuncDecl(x, metadata, fset)
 		}
 		return true
 	})
 
+	metadata.Examples = extractExamples(filepath.Dir(filePath))
+
+	return metadata, nil
+}
+
+// commentText renders a doc comment group as plain text, trimming the trailing
+// newline go/ast.CommentGroup.Text() leaves on.
+func commentText(cg *ast.CommentGroup) string {
+	if cg == nil {
+		return ""
+	}
+	return strings.TrimSpace(cg.Text())
+}
+
+// parseGoPackage type-checks every non-test file in dir with go/types and emits
+// the same GoMetadata shape as parseGoFile, but with FieldInfo.Type, ParamInfo.Type
+// and ReturnType resolved through the type checker instead of guessed from bare
+// AST identifiers. This picks up cross-file methods, embedded types declared in a
+// sibling file, and imported type references that parseGoFile cannot see.
+func parseGoPackage(dir string) (*GoMetadata, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse package: %w", err)
+	}
+
+	var pkgName string
+	var astPkg *ast.Package
+	for name, p := range pkgs {
+		if strings.HasSuffix(name, "_test") {
+			continue
+		}
+		pkgName, astPkg = name, p
+		break
+	}
+	if astPkg == nil {
+		return nil, fmt.Errorf("no buildable Go package found in %s", dir)
+	}
+
+	fileNames := make([]string, 0, len(astPkg.Files))
+	for name := range astPkg.Files {
+		fileNames = append(fileNames, name)
+	}
+	sort.Strings(fileNames)
+
+	files := make([]*ast.File, 0, len(astPkg.Files))
+	for _, name := range fileNames {
+		files = append(files, astPkg.Files[name])
+	}
+
+	var typeErrors []string
+	checkerInfo := &types.Info{
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	config := &types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Error: func(err error) {
+			typeErrors = append(typeErrors, err.Error())
+		},
+	}
+	typesPkg, _ := config.Check(pkgName, fset, files, checkerInfo)
+
+	qualifier := func(p *types.Package) string {
+		if typesPkg != nil && p == typesPkg {
+			return ""
+		}
+		return p.Name()
+	}
+
+	metadata := &GoMetadata{
+		Path:        dir,
+		PackageName: pkgName,
+		Files:       fileNames,
+		Imports:     []ImportInfo{},
+		Structs:     []StructInfo{},
+		Interfaces:  []InterfaceInfo{},
+		Functions:   []FunctionInfo{},
+		Methods:     []MethodWithReceiver{},
+		Types:       []TypeInfo{},
+		Constants:   []ConstInfo{},
+		Variables:   []VarInfo{},
+		Errors:      typeErrors,
+	}
+
+	for _, name := range fileNames {
+		f := astPkg.Files[name]
+		for _, imp := range f.Imports {
+			importInfo := ImportInfo{
+				Path: strings.Trim(imp.Path.Value, `"`),
+			}
+			if imp.Name != nil {
+				importInfo.Alias = imp.Name.Name
+				importInfo.Name = imp.Name.Name
+			} else {
+				importInfo.Name = filepath.Base(importInfo.Path)
+			}
+			metadata.Imports = append(metadata.Imports, importInfo)
+		}
+
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch x := n.(type) {
+			case *ast.GenDecl:
+				if x.Tok == token.TYPE {
+					declDoc := ""
+					if len(x.Specs) == 1 {
+						declDoc = commentText(x.Doc)
+					}
+					for _, spec := range x.Specs {
+						if ts, ok := spec.(*ast.TypeSpec); ok {
+							handleTypeSpecTyped(ts, metadata, checkerInfo, fset, qualifier, declDoc)
+						}
+					}
+					return false
+				}
+				handleGenDecl(x, metadata)
+			}
+			return true
+		})
+	}
+
+	// Functions and methods are handled only after every file's type
+	// declarations, so a method attaches to its struct even when the two are
+	// declared in different files.
+	for _, name := range fileNames {
+		for _, decl := range astPkg.Files[name].Decls {
+			if fd, ok := decl.(*ast.FuncDecl); ok {
+				handleFuncDeclTyped(fd, metadata, checkerInfo, fset, qualifier, typesPkg)
+			}
+		}
+	}
+
+	metadata.Examples = extractExamples(dir)
+
+	metadata.Implementations = computeImplementations(typesPkg)
+
 	return metadata, nil
 }
 
-func handleTypeSpec(ts *ast.TypeSpec, metadata *GoMetadata) {
+// handleTypeSpec handles a single type declaration. declDoc is the doc comment
+// of the enclosing GenDecl, used as a fallback when ts.Doc is empty: for the
+// common `// Foo does X.\ntype Foo struct{}` single-spec form, go/parser attaches
+// the comment to the GenDecl rather than the TypeSpec.
+func handleTypeSpec(ts *ast.TypeSpec, metadata *GoMetadata, declDoc string) {
 	typeName := ts.Name.Name
+	typeParams := extractTypeParams(ts.TypeParams)
+	typeParamNames := typeParamNameSet(ts.TypeParams)
+	doc := commentText(ts.Doc)
+	if doc == "" {
+		doc = declDoc
+	}
 
 	switch t := ts.Type.(type) {
 	case *ast.StructType:
 		structInfo := StructInfo{
-			Name:    typeName,
-			Type:    "struct",
-			Fields:  []FieldInfo{},
-			Methods: []MethodInfo{},
-			Embeds:  []EmbedInfo{},
+			Name:       typeName,
+			Type:       "struct",
+			Fields:     []FieldInfo{},
+			Methods:    []MethodInfo{},
+			Embeds:     []EmbedInfo{},
+			TypeParams: typeParams,
+			Doc:        doc,
 		}
 
 		if t.Fields != nil {
@@ -210,7 +475,8 @@ func handleTypeSpec(ts *ast.TypeSpec, metadata *GoMetadata) {
 					embedInfo := EmbedInfo{
 						Type:      exprToString(field.Type),
 						IsPointer: isPointerType(field.Type),
-						IsGeneric: containsGenerics(exprToString(field.Type)),
+						IsGeneric: exprReferencesNames(field.Type, typeParamNames),
+						TypeArgs:  typeArgsOf(field.Type),
 					}
 					structInfo.Embeds = append(structInfo.Embeds, embedInfo)
 				} else {
@@ -224,10 +490,13 @@ func handleTypeSpec(ts *ast.TypeSpec, metadata *GoMetadata) {
 							IsSlice:   isSliceType(field.Type),
 							IsMap:     isMapType(field.Type),
 							IsChan:    isChanType(field.Type),
-							IsGeneric: containsGenerics(exprToString(field.Type)),
+							IsGeneric: exprReferencesNames(field.Type, typeParamNames),
+							TypeArgs:  typeArgsOf(field.Type),
+							Doc:       commentText(field.Doc),
 						}
 						if field.Tag != nil {
 							fieldInfo.Tag = strings.Trim(field.Tag.Value, "`")
+							applyParsedTag(&fieldInfo)
 						}
 						structInfo.Fields = append(structInfo.Fields, fieldInfo)
 					}
@@ -239,17 +508,20 @@ func handleTypeSpec(ts *ast.TypeSpec, metadata *GoMetadata) {
 
 	case *ast.InterfaceType:
 		interfaceInfo := InterfaceInfo{
-			Name:    typeName,
-			Type:    "interface",
-			Methods: []MethodInfo{},
-			Embeds:  []string{},
+			Name:       typeName,
+			Type:       "interface",
+			Methods:    []MethodInfo{},
+			Embeds:     []string{},
+			TypeParams: typeParams,
+			Doc:        doc,
 		}
 
 		if t.Methods != nil {
 			for _, method := range t.Methods.List {
 				if len(method.Names) == 0 {
-					// Embedded interface
-					interfaceInfo.Embeds = append(interfaceInfo.Embeds, exprToString(method.Type))
+					// Embedded interface or type-set constraint element
+					embed, _ := constraintString(method.Type)
+					interfaceInfo.Embeds = append(interfaceInfo.Embeds, embed)
 				} else {
 					// Method
 					for _, name := range method.Names {
@@ -259,7 +531,8 @@ func handleTypeSpec(ts *ast.TypeSpec, metadata *GoMetadata) {
 								Parameters: extractParams(funcType.Params),
 								ReturnType: extractReturnType(funcType.Results),
 								Exported:   ast.IsExported(name.Name),
-								IsGeneric:  containsGenericsInFunc(funcType),
+								IsGeneric:  funcReferencesNames(funcType, typeParamNames),
+								Doc:        commentText(method.Doc),
 							}
 							interfaceInfo.Methods = append(interfaceInfo.Methods, methodInfo)
 						}
@@ -281,29 +554,176 @@ func handleTypeSpec(ts *ast.TypeSpec, metadata *GoMetadata) {
 	}
 }
 
-func handleFuncDecl(fd *ast.FuncDecl, metadata *GoMetadata) {
+// handleTypeSpecTyped is the go/types-aware counterpart of handleTypeSpec used by
+// parseGoPackage. It resolves field, parameter and return types through the type
+// checker so cross-file and imported references come out fully named instead of
+// as bare AST identifiers, and records the File/Line each symbol was declared at.
+func handleTypeSpecTyped(ts *ast.TypeSpec, metadata *GoMetadata, info *types.Info, fset *token.FileSet, qualifier types.Qualifier, declDoc string) {
+	typeName := ts.Name.Name
+	pos := fset.Position(ts.Pos())
+	obj := info.Defs[ts.Name]
+	typeParams := extractTypeParams(ts.TypeParams)
+	typeParamNames := typeParamNameSet(ts.TypeParams)
+	doc := commentText(ts.Doc)
+	if doc == "" {
+		doc = declDoc
+	}
+
+	switch t := ts.Type.(type) {
+	case *ast.StructType:
+		structInfo := StructInfo{
+			Name:       typeName,
+			Type:       "struct",
+			Fields:     []FieldInfo{},
+			Methods:    []MethodInfo{},
+			Embeds:     []EmbedInfo{},
+			TypeParams: typeParams,
+			File:       pos.Filename,
+			Line:       pos.Line,
+			Doc:        doc,
+		}
+
+		var structType *types.Struct
+		if obj != nil {
+			if named, ok := obj.Type().(*types.Named); ok {
+				structType, _ = named.Underlying().(*types.Struct)
+			}
+		}
+
+		if t.Fields != nil {
+			fieldIdx := 0
+			for _, field := range t.Fields.List {
+				if len(field.Names) == 0 {
+					resolvedType := exprToString(field.Type)
+					if structType != nil && fieldIdx < structType.NumFields() {
+						resolvedType = types.TypeString(structType.Field(fieldIdx).Type(), qualifier)
+					}
+					embedInfo := EmbedInfo{
+						Type:      resolvedType,
+						IsPointer: isPointerType(field.Type),
+						IsGeneric: exprReferencesNames(field.Type, typeParamNames),
+						TypeArgs:  typeArgsOf(field.Type),
+					}
+					structInfo.Embeds = append(structInfo.Embeds, embedInfo)
+					fieldIdx++
+				} else {
+					for _, name := range field.Names {
+						resolvedType := exprToString(field.Type)
+						if structType != nil && fieldIdx < structType.NumFields() {
+							resolvedType = types.TypeString(structType.Field(fieldIdx).Type(), qualifier)
+						}
+						fieldInfo := FieldInfo{
+							Name:      name.Name,
+							Type:      resolvedType,
+							Exported:  ast.IsExported(name.Name),
+							IsPointer: isPointerType(field.Type),
+							IsSlice:   isSliceType(field.Type),
+							IsMap:     isMapType(field.Type),
+							IsChan:    isChanType(field.Type),
+							IsGeneric: exprReferencesNames(field.Type, typeParamNames),
+							TypeArgs:  typeArgsOf(field.Type),
+							Doc:       commentText(field.Doc),
+						}
+						if field.Tag != nil {
+							fieldInfo.Tag = strings.Trim(field.Tag.Value, "`")
+							applyParsedTag(&fieldInfo)
+						}
+						structInfo.Fields = append(structInfo.Fields, fieldInfo)
+						fieldIdx++
+					}
+				}
+			}
+		}
+
+		metadata.Structs = append(metadata.Structs, structInfo)
+
+	case *ast.InterfaceType:
+		interfaceInfo := InterfaceInfo{
+			Name:       typeName,
+			Type:       "interface",
+			Methods:    []MethodInfo{},
+			Embeds:     []string{},
+			TypeParams: typeParams,
+			File:       pos.Filename,
+			Line:       pos.Line,
+			Doc:        doc,
+		}
+
+		if t.Methods != nil {
+			for _, method := range t.Methods.List {
+				if len(method.Names) == 0 {
+					embed, _ := constraintString(method.Type)
+					interfaceInfo.Embeds = append(interfaceInfo.Embeds, embed)
+				} else {
+					for _, name := range method.Names {
+						if funcType, ok := method.Type.(*ast.FuncType); ok {
+							methodInfo := MethodInfo{
+								Name:       name.Name,
+								Parameters: extractParams(funcType.Params),
+								ReturnType: extractReturnType(funcType.Results),
+								Exported:   ast.IsExported(name.Name),
+								IsGeneric:  funcReferencesNames(funcType, typeParamNames),
+								Doc:        commentText(method.Doc),
+							}
+							if methodObj := info.Defs[name]; methodObj != nil {
+								if sig, ok := methodObj.Type().(*types.Signature); ok {
+									methodInfo.Parameters = extractParamsTyped(sig, qualifier)
+									methodInfo.ReturnType = extractReturnTypeTyped(sig, qualifier)
+								}
+							}
+							interfaceInfo.Methods = append(interfaceInfo.Methods, methodInfo)
+						}
+					}
+				}
+			}
+		}
+
+		metadata.Interfaces = append(metadata.Interfaces, interfaceInfo)
+
+	default:
+		resolvedUnderlying := exprToString(ts.Type)
+		if obj != nil {
+			resolvedUnderlying = types.TypeString(obj.Type().Underlying(), qualifier)
+		}
+		typeInfo := TypeInfo{
+			Name:           typeName,
+			Type:           "alias",
+			UnderlyingType: resolvedUnderlying,
+		}
+		metadata.Types = append(metadata.Types, typeInfo)
+	}
+}
+
+func handleFuncDecl(fd *ast.FuncDecl, metadata *GoMetadata, fset *token.FileSet) {
 	if fd.Recv != nil {
 		// Method
 		receiver := ReceiverInfo{}
+		var recvTypeParams map[string]bool
 		if len(fd.Recv.List) > 0 {
 			recv := fd.Recv.List[0]
 			if len(recv.Names) > 0 {
 				receiver.Name = recv.Names[0].Name
 			}
 			receiver.Type = exprToString(recv.Type)
+			recvTypeParams = receiverTypeParamNames(recv.Type)
 		}
 
+		reads, writes := extractFieldAccess(fd, receiver.Name)
 		methodInfo := MethodWithReceiver{
-			Name:       fd.Name.Name,
-			Receiver:   receiver,
-			Parameters: extractParams(fd.Type.Params),
-			ReturnType: extractReturnType(fd.Type.Results),
-			Exported:   ast.IsExported(fd.Name.Name),
+			Name:         fd.Name.Name,
+			Receiver:     receiver,
+			Parameters:   extractParams(fd.Type.Params),
+			ReturnType:   extractReturnType(fd.Type.Results),
+			Exported:     ast.IsExported(fd.Name.Name),
+			Doc:          commentText(fd.Doc),
+			Calls:        extractCalls(fset, fd),
+			ReadsFields:  reads,
+			WritesFields: writes,
 		}
 		metadata.Methods = append(metadata.Methods, methodInfo)
 
 		// Also add to the corresponding struct
-		receiverTypeName := strings.TrimPrefix(receiver.Type, "*")
+		receiverTypeName := baseReceiverTypeName(receiver.Type)
 		for i := range metadata.Structs {
 			if metadata.Structs[i].Name == receiverTypeName {
 				structMethod := MethodInfo{
@@ -311,7 +731,8 @@ func handleFuncDecl(fd *ast.FuncDecl, metadata *GoMetadata) {
 					Parameters: methodInfo.Parameters,
 					ReturnType: methodInfo.ReturnType,
 					Exported:   methodInfo.Exported,
-					IsGeneric:  containsGenericsInFunc(fd.Type),
+					IsGeneric:  funcReferencesNames(fd.Type, recvTypeParams),
+					Doc:        methodInfo.Doc,
 				}
 				metadata.Structs[i].Methods = append(metadata.Structs[i].Methods, structMethod)
 				break
@@ -324,20 +745,415 @@ func handleFuncDecl(fd *ast.FuncDecl, metadata *GoMetadata) {
 			Parameters: extractParams(fd.Type.Params),
 			ReturnType: extractReturnType(fd.Type.Results),
 			Exported:   ast.IsExported(fd.Name.Name),
+			TypeParams: extractTypeParams(fd.Type.TypeParams),
+			Doc:        commentText(fd.Doc),
+			Calls:      extractCalls(fset, fd),
+		}
+		metadata.Functions = append(metadata.Functions, functionInfo)
+	}
+}
+
+// handleFuncDeclTyped is the go/types-aware counterpart of handleFuncDecl used by
+// parseGoPackage. It resolves parameter and return types through types.Info.
+// parseGoPackage calls it only after the type declarations of every file have
+// been collected, so a method is matched to its struct by receiver type name
+// across the whole package rather than within a single file.
+func handleFuncDeclTyped(fd *ast.FuncDecl, metadata *GoMetadata, info *types.Info, fset *token.FileSet, qualifier types.Qualifier, pkg *types.Package) {
+	pos := fset.Position(fd.Pos())
+	var sig *types.Signature
+	if obj := info.Defs[fd.Name]; obj != nil {
+		sig, _ = obj.Type().(*types.Signature)
+	}
+
+	if fd.Recv != nil {
+		receiver := ReceiverInfo{}
+		var recvTypeParams map[string]bool
+		if len(fd.Recv.List) > 0 {
+			recv := fd.Recv.List[0]
+			if len(recv.Names) > 0 {
+				receiver.Name = recv.Names[0].Name
+			}
+			receiver.Type = exprToString(recv.Type)
+			recvTypeParams = receiverTypeParamNames(recv.Type)
+		}
+
+		params := extractParams(fd.Type.Params)
+		returnType := extractReturnType(fd.Type.Results)
+		if sig != nil {
+			params = extractParamsTyped(sig, qualifier)
+			returnType = extractReturnTypeTyped(sig, qualifier)
+		}
+
+		reads, writes := extractFieldAccess(fd, receiver.Name)
+		methodInfo := MethodWithReceiver{
+			Name:         fd.Name.Name,
+			Receiver:     receiver,
+			Parameters:   params,
+			ReturnType:   returnType,
+			Exported:     ast.IsExported(fd.Name.Name),
+			File:         pos.Filename,
+			Line:         pos.Line,
+			Doc:          commentText(fd.Doc),
+			Calls:        extractCallsTyped(fset, fd, info),
+			UsesGlobals:  extractGlobalUses(fd, info, pkg),
+			ReadsFields:  reads,
+			WritesFields: writes,
+		}
+		metadata.Methods = append(metadata.Methods, methodInfo)
+
+		receiverTypeName := baseReceiverTypeName(receiver.Type)
+		for i := range metadata.Structs {
+			if metadata.Structs[i].Name == receiverTypeName {
+				structMethod := MethodInfo{
+					Name:       fd.Name.Name,
+					Parameters: methodInfo.Parameters,
+					ReturnType: methodInfo.ReturnType,
+					Exported:   methodInfo.Exported,
+					IsGeneric:  funcReferencesNames(fd.Type, recvTypeParams),
+					Doc:        methodInfo.Doc,
+				}
+				metadata.Structs[i].Methods = append(metadata.Structs[i].Methods, structMethod)
+				break
+			}
+		}
+	} else {
+		params := extractParams(fd.Type.Params)
+		returnType := extractReturnType(fd.Type.Results)
+		if sig != nil {
+			params = extractParamsTyped(sig, qualifier)
+			returnType = extractReturnTypeTyped(sig, qualifier)
+		}
+
+		functionInfo := FunctionInfo{
+			Name:       fd.Name.Name,
+			Parameters: params,
+			ReturnType: returnType,
+			Exported:   ast.IsExported(fd.Name.Name),
+			TypeParams: extractTypeParams(fd.Type.TypeParams),
+			File:       pos.Filename,
+			Line:       pos.Line,
+			Doc:        commentText(fd.Doc),
+			Calls:      extractCallsTyped(fset, fd, info),
 		}
 		metadata.Functions = append(metadata.Functions, functionInfo)
 	}
 }
 
+// extractCalls walks fn's body collecting every call expression as a CallSite
+// using AST shape alone. Package and IsInterface are left unset since telling
+// a concrete method call from an interface call requires the type information
+// only available in whole-package mode; see extractCallsTyped.
+func extractCalls(fset *token.FileSet, fn *ast.FuncDecl) []CallSite {
+	if fn.Body == nil {
+		return nil
+	}
+
+	var calls []CallSite
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		switch fun := call.Fun.(type) {
+		case *ast.Ident:
+			calls = append(calls, CallSite{
+				Callee: fun.Name,
+				Pos:    fset.Position(call.Pos()).String(),
+			})
+		case *ast.SelectorExpr:
+			calls = append(calls, CallSite{
+				Callee:   fun.Sel.Name,
+				Receiver: exprToString(fun.X),
+				Pos:      fset.Position(call.Pos()).String(),
+			})
+		}
+		return true
+	})
+	return calls
+}
+
+// extractCallsTyped is the go/types-aware counterpart of extractCalls used by
+// parseGoPackage. Resolving each callee through info.Uses/info.Selections lets
+// Package and IsInterface say whether a call reaches a free function, a method
+// on a concrete type, or a method promised only by an interface value — the
+// signal the adaptive-tests engine needs to decide which collaborators need
+// mocking in a scaffolded test.
+func extractCallsTyped(fset *token.FileSet, fn *ast.FuncDecl, info *types.Info) []CallSite {
+	if fn.Body == nil {
+		return nil
+	}
+
+	var calls []CallSite
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		site := CallSite{Pos: fset.Position(call.Pos()).String()}
+		switch fun := call.Fun.(type) {
+		case *ast.Ident:
+			site.Callee = fun.Name
+			if obj := info.Uses[fun]; obj != nil && obj.Pkg() != nil {
+				site.Package = obj.Pkg().Name()
+			}
+		case *ast.SelectorExpr:
+			site.Callee = fun.Sel.Name
+			site.Receiver = exprToString(fun.X)
+			if sel, ok := info.Selections[fun]; ok {
+				site.IsInterface = isInterfaceReceiver(sel.Recv())
+				if named := underlyingNamed(sel.Recv()); named != nil {
+					if obj := named.Obj(); obj.Pkg() != nil {
+						site.Package = obj.Pkg().Name()
+					}
+				}
+			} else if obj := info.Uses[fun.Sel]; obj != nil && obj.Pkg() != nil {
+				site.Package = obj.Pkg().Name()
+			}
+		default:
+			return true
+		}
+		calls = append(calls, site)
+		return true
+	})
+	return calls
+}
+
+// isInterfaceReceiver reports whether t (a selection's receiver type, possibly
+// a pointer) is an interface, meaning the call was made through an interface
+// value rather than a concrete type.
+func isInterfaceReceiver(t types.Type) bool {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	_, ok := t.Underlying().(*types.Interface)
+	return ok
+}
+
+// underlyingNamed unwraps a pointer to find the *types.Named a selection's
+// receiver is declared as, or nil if the receiver isn't a named type.
+func underlyingNamed(t types.Type) *types.Named {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, _ := t.(*types.Named)
+	return named
+}
+
+// extractFieldAccess walks fn's body for direct receiver.field selector
+// expressions, splitting the fields it touches into reads and writes based on
+// whether the selector is the target of an assignment or increment/decrement.
+// Selectors that are the callee of a call expression (receiver.Method(...))
+// are excluded entirely, since those are method calls already reported in
+// Calls, not field access. It works from AST shape alone, so it applies in
+// both single-file and whole-package mode.
+func extractFieldAccess(fn *ast.FuncDecl, receiverName string) (reads []string, writes []string) {
+	if fn.Body == nil || receiverName == "" {
+		return nil, nil
+	}
+
+	writeTargets := map[*ast.SelectorExpr]bool{}
+	callTargets := map[*ast.SelectorExpr]bool{}
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch stmt := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range stmt.Lhs {
+				if sel, ok := lhs.(*ast.SelectorExpr); ok {
+					writeTargets[sel] = true
+				}
+			}
+		case *ast.IncDecStmt:
+			if sel, ok := stmt.X.(*ast.SelectorExpr); ok {
+				writeTargets[sel] = true
+			}
+		case *ast.CallExpr:
+			if sel, ok := stmt.Fun.(*ast.SelectorExpr); ok {
+				callTargets[sel] = true
+			}
+		}
+		return true
+	})
+
+	seenRead := map[string]bool{}
+	seenWrite := map[string]bool{}
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		ident, ok := sel.X.(*ast.Ident)
+		if !ok || ident.Name != receiverName {
+			return true
+		}
+		if callTargets[sel] {
+			return true
+		}
+
+		if writeTargets[sel] {
+			if !seenWrite[sel.Sel.Name] {
+				seenWrite[sel.Sel.Name] = true
+				writes = append(writes, sel.Sel.Name)
+			}
+		} else if !seenRead[sel.Sel.Name] {
+			seenRead[sel.Sel.Name] = true
+			reads = append(reads, sel.Sel.Name)
+		}
+		return true
+	})
+	return reads, writes
+}
+
+// extractGlobalUses walks fn's body for identifiers that resolve to a
+// package-level var or const, so the adaptive-tests engine can tell shared
+// package state apart from the method's own collaborators.
+func extractGlobalUses(fn *ast.FuncDecl, info *types.Info, pkg *types.Package) []string {
+	if fn.Body == nil || pkg == nil {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	var globals []string
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		obj := info.Uses[ident]
+		if obj == nil {
+			return true
+		}
+		switch obj.(type) {
+		case *types.Var, *types.Const:
+		default:
+			return true
+		}
+		if obj.Parent() != pkg.Scope() {
+			return true
+		}
+		if !seen[obj.Name()] {
+			seen[obj.Name()] = true
+			globals = append(globals, obj.Name())
+		}
+		return true
+	})
+	return globals
+}
+
+// computeImplementations collects the named interfaces with at least one method
+// declared in pkg or exported by one of its direct imports, and the named
+// non-interface types declared in pkg itself, then tests each (type, interface)
+// pair with types.Implements to find which concrete types back which interfaces.
+func computeImplementations(pkg *types.Package) []ImplementationInfo {
+	if pkg == nil {
+		return nil
+	}
+
+	interfaces, concreteTypes := namedTypesInScope(pkg, true)
+	for _, imp := range pkg.Imports() {
+		importedInterfaces, _ := namedTypesInScope(imp, false)
+		interfaces = append(interfaces, importedInterfaces...)
+	}
+
+	var result []ImplementationInfo
+	for _, iface := range interfaces {
+		ifaceType, ok := iface.Underlying().(*types.Interface)
+		if !ok {
+			continue
+		}
+		ifaceName := qualifiedTypeName(pkg, iface)
+
+		for _, t := range concreteTypes {
+			valueOK := types.Implements(t, ifaceType)
+			ptrOK := types.Implements(types.NewPointer(t), ifaceType)
+			if !valueOK && !ptrOK {
+				continue
+			}
+
+			receiverKind := "value"
+			methodSet := types.NewMethodSet(t)
+			if !valueOK {
+				receiverKind = "pointer"
+				methodSet = types.NewMethodSet(types.NewPointer(t))
+			}
+
+			methods := make([]string, 0, ifaceType.NumMethods())
+			for i := 0; i < ifaceType.NumMethods(); i++ {
+				m := ifaceType.Method(i)
+				if methodSet.Lookup(m.Pkg(), m.Name()) != nil {
+					methods = append(methods, m.Name())
+				}
+			}
+
+			result = append(result, ImplementationInfo{
+				Interface:    ifaceName,
+				Type:         t.Obj().Name(),
+				ReceiverKind: receiverKind,
+				Methods:      methods,
+			})
+		}
+	}
+
+	return result
+}
+
+// namedTypesInScope splits a package's top-level named types into interfaces
+// with at least one method and named non-interface (potential candidate) types;
+// interfaces without methods appear in neither list.
+// Unexported names are skipped unless includeUnexported is set, since an
+// imported package's unexported interfaces can never be implemented from here.
+func namedTypesInScope(pkg *types.Package, includeUnexported bool) (interfaces, concreteTypes []*types.Named) {
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		if !includeUnexported && !ast.IsExported(name) {
+			continue
+		}
+		tn, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok {
+			continue
+		}
+		named, ok := tn.Type().(*types.Named)
+		if !ok {
+			continue
+		}
+		if iface, ok := named.Underlying().(*types.Interface); ok {
+			if iface.NumMethods() > 0 {
+				interfaces = append(interfaces, named)
+			}
+			continue
+		}
+		concreteTypes = append(concreteTypes, named)
+	}
+	return interfaces, concreteTypes
+}
+
+// qualifiedTypeName renders a named type's identifier, prefixing it with its
+// package name when it was declared outside pkg.
+func qualifiedTypeName(pkg *types.Package, named *types.Named) string {
+	obj := named.Obj()
+	if obj.Pkg() == nil || obj.Pkg() == pkg {
+		return obj.Name()
+	}
+	return obj.Pkg().Name() + "." + obj.Name()
+}
+
 func handleGenDecl(gd *ast.GenDecl, metadata *GoMetadata) {
+	declDoc := ""
+	if len(gd.Specs) == 1 {
+		declDoc = commentText(gd.Doc)
+	}
 	switch gd.Tok {
 	case token.CONST:
 		for _, spec := range gd.Specs {
 			if valueSpec, ok := spec.(*ast.ValueSpec); ok {
+				doc := commentText(valueSpec.Doc)
+				if doc == "" {
+					doc = declDoc
+				}
 				for i, name := range valueSpec.Names {
 					constInfo := ConstInfo{
 						Name:     name.Name,
 						Exported: ast.IsExported(name.Name),
+						Doc:      doc,
 					}
 					if valueSpec.Type != nil {
 						constInfo.Type = exprToString(valueSpec.Type)
@@ -352,10 +1168,15 @@ func handleGenDecl(gd *ast.GenDecl, metadata *GoMetadata) {
 	case token.VAR:
 		for _, spec := range gd.Specs {
 			if valueSpec, ok := spec.(*ast.ValueSpec); ok {
+				doc := commentText(valueSpec.Doc)
+				if doc == "" {
+					doc = declDoc
+				}
 				for i, name := range valueSpec.Names {
 					varInfo := VarInfo{
 						Name:     name.Name,
 						Exported: ast.IsExported(name.Name),
+						Doc:      doc,
 					}
 					if valueSpec.Type != nil {
 						varInfo.Type = exprToString(valueSpec.Type)
@@ -413,6 +1234,46 @@ func extractReturnType(results *ast.FieldList) string {
 	return "(" + strings.Join(types, ", ") + ")"
 }
 
+// extractParamsTyped is the go/types-aware counterpart of extractParams: it reads
+// parameter names and types off a resolved *types.Signature instead of the bare
+// AST, so variadic parameters and cross-package types come out correctly named.
+func extractParamsTyped(sig *types.Signature, qualifier types.Qualifier) []ParamInfo {
+	params := sig.Params()
+	result := make([]ParamInfo, 0, params.Len())
+	for i := 0; i < params.Len(); i++ {
+		p := params.At(i)
+		typeStr := types.TypeString(p.Type(), qualifier)
+		if sig.Variadic() && i == params.Len()-1 {
+			if slice, ok := p.Type().(*types.Slice); ok {
+				typeStr = "..." + types.TypeString(slice.Elem(), qualifier)
+			}
+		}
+		result = append(result, ParamInfo{
+			Name: p.Name(),
+			Type: typeStr,
+		})
+	}
+	return result
+}
+
+// extractReturnTypeTyped is the go/types-aware counterpart of extractReturnType.
+func extractReturnTypeTyped(sig *types.Signature, qualifier types.Qualifier) string {
+	results := sig.Results()
+	if results.Len() == 0 {
+		return "void"
+	}
+
+	typeStrs := make([]string, 0, results.Len())
+	for i := 0; i < results.Len(); i++ {
+		typeStrs = append(typeStrs, types.TypeString(results.At(i).Type(), qualifier))
+	}
+
+	if len(typeStrs) == 1 {
+		return typeStrs[0]
+	}
+	return "(" + strings.Join(typeStrs, ", ") + ")"
+}
+
 func exprToString(expr ast.Expr) string {
 	if expr == nil {
 		return ""
@@ -491,24 +1352,345 @@ func isChanType(expr ast.Expr) bool {
 	return ok
 }
 
-func containsGenerics(typeStr string) bool {
-	return strings.Contains(typeStr, "[") && strings.Contains(typeStr, "]") &&
-		!strings.HasPrefix(typeStr, "[]") && !strings.HasPrefix(typeStr, "map[")
+// extractTypeParams reads a generic declaration's type parameter list off its
+// AST field list (ast.TypeSpec.TypeParams or ast.FuncType.TypeParams), stringifying
+// each constraint and, for union constraints, splitting out the individual terms.
+func extractTypeParams(fields *ast.FieldList) []TypeParamInfo {
+	if fields == nil {
+		return nil
+	}
+
+	var result []TypeParamInfo
+	for _, field := range fields.List {
+		constraint, terms := constraintString(field.Type)
+		for _, name := range field.Names {
+			result = append(result, TypeParamInfo{
+				Name:            name.Name,
+				Constraint:      constraint,
+				ConstraintTerms: terms,
+			})
+		}
+	}
+	return result
+}
+
+// constraintString renders a type-parameter or constraint-interface element's
+// constraint expression as source-like text, splitting out the individual
+// terms of a union constraint such as `~int | ~float64` along the way.
+// ConstraintTerms (the second return value) is only non-empty for unions and
+// approximation elements; for a plain type it's nil.
+func constraintString(expr ast.Expr) (string, []string) {
+	var terms []string
+	switch e := expr.(type) {
+	case *ast.BinaryExpr:
+		if e.Op == token.OR {
+			terms = splitConstraintTerms(e)
+		}
+	case *ast.UnaryExpr:
+		if e.Op == token.TILDE {
+			terms = splitConstraintTerms(e)
+		}
+	}
+	if len(terms) > 0 {
+		return strings.Join(terms, " | "), terms
+	}
+	return exprToString(expr), terms
+}
+
+// splitConstraintTerms breaks a union constraint such as `~int | ~float64` into
+// its individual terms, preserving each term's `~` approximation flag.
+func splitConstraintTerms(expr ast.Expr) []string {
+	switch e := expr.(type) {
+	case *ast.BinaryExpr:
+		if e.Op == token.OR {
+			return append(splitConstraintTerms(e.X), splitConstraintTerms(e.Y)...)
+		}
+	case *ast.UnaryExpr:
+		if e.Op == token.TILDE {
+			return []string{"~" + exprToString(e.X)}
+		}
+	}
+	return []string{exprToString(expr)}
+}
+
+// typeParamNameSet collects the names declared by a type parameter list, for use
+// with exprReferencesNames/funcReferencesNames.
+func typeParamNameSet(fields *ast.FieldList) map[string]bool {
+	if fields == nil {
+		return nil
+	}
+	names := make(map[string]bool, len(fields.List))
+	for _, field := range fields.List {
+		for _, name := range field.Names {
+			names[name.Name] = true
+		}
+	}
+	return names
+}
+
+// receiverTypeParamNames extracts the type parameter names a method's receiver
+// instantiates, e.g. the `T` in `func (s *Stack[T]) Push(v T)`.
+func receiverTypeParamNames(recvType ast.Expr) map[string]bool {
+	switch e := recvType.(type) {
+	case *ast.StarExpr:
+		return receiverTypeParamNames(e.X)
+	case *ast.IndexExpr:
+		if id, ok := e.Index.(*ast.Ident); ok {
+			return map[string]bool{id.Name: true}
+		}
+	case *ast.IndexListExpr:
+		names := make(map[string]bool, len(e.Indices))
+		for _, idx := range e.Indices {
+			if id, ok := idx.(*ast.Ident); ok {
+				names[id.Name] = true
+			}
+		}
+		return names
+	}
+	return nil
+}
+
+// baseReceiverTypeName strips a method receiver's leading pointer and any type
+// argument list, so `*Stack[T]` matches the StructInfo named `Stack`.
+func baseReceiverTypeName(recvType string) string {
+	name := strings.TrimPrefix(recvType, "*")
+	if idx := strings.IndexByte(name, '['); idx >= 0 {
+		name = name[:idx]
+	}
+	return name
 }
 
-func containsGenericsInFunc(funcType *ast.FuncType) bool {
-	if funcType.TypeParams != nil && len(funcType.TypeParams.List) > 0 {
+// exprReferencesNames reports whether expr mentions any identifier in names,
+// which handleTypeSpec(Typed) use to decide whether a field or embed is still
+// parameterized by one of the enclosing declaration's own type parameters,
+// as opposed to a fully instantiated concrete type like Foo[int].
+func exprReferencesNames(expr ast.Expr, names map[string]bool) bool {
+	if len(names) == 0 || expr == nil {
+		return false
+	}
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		if id, ok := n.(*ast.Ident); ok && names[id.Name] {
+			found = true
+			return false
+		}
 		return true
+	})
+	return found
+}
+
+// funcReferencesNames reports whether any parameter or result type in funcType
+// references one of names.
+func funcReferencesNames(funcType *ast.FuncType, names map[string]bool) bool {
+	if len(names) == 0 {
+		return false
+	}
+	if funcType.Params != nil {
+		for _, param := range funcType.Params.List {
+			if exprReferencesNames(param.Type, names) {
+				return true
+			}
+		}
+	}
+	if funcType.Results != nil {
+		for _, result := range funcType.Results.List {
+			if exprReferencesNames(result.Type, names) {
+				return true
+			}
+		}
 	}
+	return false
+}
 
-	// Check parameters for generic types
-	for _, param := range extractParams(funcType.Params) {
-		if containsGenerics(param.Type) {
-			return true
+// typeArgsOf returns a generic instantiation's type arguments as written in the
+// source, e.g. ["int"] for Foo[int] and ["string", "int"] for Pair[string, int].
+// It returns nil for a type that isn't an instantiation.
+func typeArgsOf(expr ast.Expr) []string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return typeArgsOf(e.X)
+	case *ast.IndexExpr:
+		return []string{exprToString(e.Index)}
+	case *ast.IndexListExpr:
+		args := make([]string, 0, len(e.Indices))
+		for _, idx := range e.Indices {
+			args = append(args, exprToString(idx))
+		}
+		return args
+	default:
+		return nil
+	}
+}
+
+// structTagKeys lists the struct tag keys parseFieldTag understands, each in the
+// standard `key:"value,opt1,opt2"` form.
+var structTagKeys = []string{"json", "yaml", "xml", "bson", "gorm", "validate", "form", "db", "protobuf"}
+
+// parseFieldTag reads the known struct tag keys out of a raw (backtick-stripped)
+// tag string, splitting each one's value on commas into a name plus options the
+// way encoding/json does.
+func parseFieldTag(raw string) map[string]TagValue {
+	if raw == "" {
+		return nil
+	}
+
+	tag := reflect.StructTag(raw)
+	parsed := make(map[string]TagValue)
+	for _, key := range structTagKeys {
+		value, ok := tag.Lookup(key)
+		if !ok {
+			continue
+		}
+		parts := strings.Split(value, ",")
+		tagValue := TagValue{
+			Name: parts[0],
+			Raw:  value,
+		}
+		if len(parts) > 1 {
+			tagValue.Options = parts[1:]
+		}
+		parsed[key] = tagValue
+	}
+
+	if len(parsed) == 0 {
+		return nil
+	}
+	return parsed
+}
+
+// applyParsedTag fills in fieldInfo.ParsedTag and the derived OmitEmpty, JSONName,
+// JSONIgnore and DBColumn conveniences from fieldInfo.Tag, so scaffolders generating
+// request and response fixtures don't need a second pass over the raw tag string.
+func applyParsedTag(fieldInfo *FieldInfo) {
+	fieldInfo.ParsedTag = parseFieldTag(fieldInfo.Tag)
+	if fieldInfo.ParsedTag == nil {
+		return
+	}
+
+	if jsonTag, ok := fieldInfo.ParsedTag["json"]; ok {
+		if jsonTag.Name == "-" && len(jsonTag.Options) == 0 {
+			fieldInfo.JSONIgnore = true
+		} else {
+			fieldInfo.JSONName = jsonTag.Name
+			if fieldInfo.JSONName == "" {
+				fieldInfo.JSONName = fieldInfo.Name
+			}
+		}
+		for _, opt := range jsonTag.Options {
+			if opt == "omitempty" {
+				fieldInfo.OmitEmpty = true
+			}
 		}
 	}
 
-	// Check return type for generics
-	returnType := extractReturnType(funcType.Results)
-	return containsGenerics(returnType)
-}
\ No newline at end of file
+	if dbTag, ok := fieldInfo.ParsedTag["db"]; ok && dbTag.Name != "" {
+		fieldInfo.DBColumn = dbTag.Name
+	} else if gormTag, ok := fieldInfo.ParsedTag["gorm"]; ok && gormTag.Name != "" {
+		fieldInfo.DBColumn = gormTag.Name
+	}
+}
+
+// extractExamples scans the _test.go files in dir for Go's standard
+// ExampleXxx functions and returns every one that has a body, so
+// discovery tools can surface documentation examples alongside the
+// declarations they illustrate.
+func extractExamples(dir string) []ExampleInfo {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var examples []ExampleInfo
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, entry.Name()), nil, parser.ParseComments)
+		if err != nil {
+			continue
+		}
+
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Recv != nil || fd.Body == nil {
+				continue
+			}
+			if !strings.HasPrefix(fd.Name.Name, "Example") {
+				continue
+			}
+
+			examples = append(examples, ExampleInfo{
+				Name:   fd.Name.Name,
+				Target: exampleTarget(fd.Name.Name),
+				Code:   formatNode(fset, fd.Body),
+				Output: exampleOutput(file, fd),
+			})
+		}
+	}
+	return examples
+}
+
+// exampleTarget derives the declaration an ExampleXxx function documents from
+// its name: ExampleFoo documents Foo, ExampleFoo_Bar documents Foo.Bar, and a
+// bare Example (or Example_) documents the package as a whole, giving "".
+// The first underscore always becomes a dot; go/doc's lowercase example
+// suffixes are not told apart, so ExampleFoo_second gives "Foo.second" and
+// Example_second gives "second".
+func exampleTarget(name string) string {
+	rest := strings.TrimPrefix(name, "Example")
+	if rest == "" {
+		return ""
+	}
+	rest = strings.TrimPrefix(rest, "_")
+	if rest == "" {
+		return ""
+	}
+	return strings.Replace(rest, "_", ".", 1)
+}
+
+// formatNode renders an AST node back to source text using the same
+// FileSet it was parsed with, so Code reflects the example's original
+// formatting rather than a field-by-field reconstruction.
+func formatNode(fset *token.FileSet, node ast.Node) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, node); err != nil {
+		return ""
+	}
+	return buf.String()
+}
+
+// exampleOutput extracts the expected output of an Example function from
+// its trailing "// Output:" or "// Unordered output:" comment, matching
+// the convention `go test` uses to verify example output.
+func exampleOutput(file *ast.File, fd *ast.FuncDecl) string {
+	if fd.Body == nil {
+		return ""
+	}
+
+	var last *ast.CommentGroup
+	for _, cg := range file.Comments {
+		if cg.Pos() < fd.Body.Lbrace || cg.Pos() > fd.Body.Rbrace {
+			continue
+		}
+		last = cg
+	}
+	if last == nil {
+		return ""
+	}
+
+	text := last.Text()
+	lower := strings.ToLower(text)
+	switch {
+	case strings.HasPrefix(lower, "unordered output:"):
+		return strings.TrimSpace(text[len("unordered output:"):])
+	case strings.HasPrefix(lower, "output:"):
+		return strings.TrimSpace(text[len("output:"):])
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"testing"
+)
+
+// typeParamFields parses a single generic func decl and returns its type
+// parameter field list, for feeding straight into extractTypeParams.
+func typeParamFields(t *testing.T, src string) *ast.FieldList {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "fixture.go", "package fixture\n"+src, 0)
+	if err != nil {
+		t.Fatalf("parse fixture: %v", err)
+	}
+	fd, ok := file.Decls[0].(*ast.FuncDecl)
+	if !ok {
+		t.Fatalf("fixture is not a func decl")
+	}
+	return fd.Type.TypeParams
+}
+
+func TestExtractTypeParams_UnionConstraint(t *testing.T) {
+	fields := typeParamFields(t, "func Clamp[T ~int | ~float64](v, lo, hi T) T { return v }")
+	params := extractTypeParams(fields)
+	if len(params) != 1 {
+		t.Fatalf("got %d type params, want 1", len(params))
+	}
+	got := params[0]
+	if got.Constraint != "~int | ~float64" {
+		t.Errorf("Constraint = %q, want %q", got.Constraint, "~int | ~float64")
+	}
+	wantTerms := []string{"~int", "~float64"}
+	if len(got.ConstraintTerms) != len(wantTerms) {
+		t.Fatalf("ConstraintTerms = %v, want %v", got.ConstraintTerms, wantTerms)
+	}
+	for i, term := range wantTerms {
+		if got.ConstraintTerms[i] != term {
+			t.Errorf("ConstraintTerms[%d] = %q, want %q", i, got.ConstraintTerms[i], term)
+		}
+	}
+}
+
+func TestExtractTypeParams_PlainConstraint(t *testing.T) {
+	fields := typeParamFields(t, "func First[T any](s []T) T { return s[0] }")
+	params := extractTypeParams(fields)
+	if len(params) != 1 {
+		t.Fatalf("got %d type params, want 1", len(params))
+	}
+	if params[0].Constraint != "any" {
+		t.Errorf("Constraint = %q, want %q", params[0].Constraint, "any")
+	}
+	if len(params[0].ConstraintTerms) != 0 {
+		t.Errorf("ConstraintTerms = %v, want none for a non-union constraint", params[0].ConstraintTerms)
+	}
+}
+
+// TestHandleTypeSpec_ConstraintInterfaceEmbeds covers a named constraint
+// interface whose body is only a type-set union, e.g.
+//
+//	type Number interface { ~int | ~float64 }
+//
+// which previously rendered its Embeds entry as the literal string "unknown"
+// because exprToString has no case for *ast.BinaryExpr/*ast.UnaryExpr.
+func TestHandleTypeSpec_ConstraintInterfaceEmbeds(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "fixture.go", `package fixture
+
+type Number interface {
+	~int | ~float64
+}
+`, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parse fixture: %v", err)
+	}
+
+	metadata := &GoMetadata{Interfaces: []InterfaceInfo{}}
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			handleTypeSpec(ts, metadata, "")
+		}
+	}
+
+	if len(metadata.Interfaces) != 1 {
+		t.Fatalf("got %d interfaces, want 1", len(metadata.Interfaces))
+	}
+	embeds := metadata.Interfaces[0].Embeds
+	if len(embeds) != 1 || embeds[0] != "~int | ~float64" {
+		t.Errorf("Embeds = %v, want [%q]", embeds, "~int | ~float64")
+	}
+}
+
+// TestApplyParsedTag_JSONDash covers the two tags that only differ by a
+// trailing comma: `json:"-"` excludes the field from JSON entirely, while
+// `json:"-,"` gives it the literal JSON name "-". Only the former should set
+// JSONIgnore.
+func TestApplyParsedTag_JSONDash(t *testing.T) {
+	ignored := &FieldInfo{Name: "Secret", Tag: `json:"-"`}
+	applyParsedTag(ignored)
+	if !ignored.JSONIgnore {
+		t.Errorf("JSONIgnore = false, want true for json:\"-\"")
+	}
+	if ignored.JSONName != "" {
+		t.Errorf("JSONName = %q, want empty for json:\"-\"", ignored.JSONName)
+	}
+
+	literalDash := &FieldInfo{Name: "Dash", Tag: `json:"-,"`}
+	applyParsedTag(literalDash)
+	if literalDash.JSONIgnore {
+		t.Errorf("JSONIgnore = true, want false for json:\"-,\"")
+	}
+	if literalDash.JSONName != "-" {
+		t.Errorf("JSONName = %q, want %q for json:\"-,\"", literalDash.JSONName, "-")
+	}
+}
+
+func TestApplyParsedTag_NamedField(t *testing.T) {
+	field := &FieldInfo{Name: "UserID", Tag: `json:"user_id,omitempty" db:"user_id"`}
+	applyParsedTag(field)
+	if field.JSONName != "user_id" {
+		t.Errorf("JSONName = %q, want %q", field.JSONName, "user_id")
+	}
+	if !field.OmitEmpty {
+		t.Errorf("OmitEmpty = false, want true")
+	}
+	if field.JSONIgnore {
+		t.Errorf("JSONIgnore = true, want false")
+	}
+	if field.DBColumn != "user_id" {
+		t.Errorf("DBColumn = %q, want %q", field.DBColumn, "user_id")
+	}
+}
+
+// methodFuncDecl parses a single method declaration on receiver name "s" and
+// returns its *ast.FuncDecl, for feeding straight into extractFieldAccess.
+func methodFuncDecl(t *testing.T, body string) *ast.FuncDecl {
+	t.Helper()
+	fset := token.NewFileSet()
+	src := "package fixture\n\ntype Svc struct{ Name string }\n\nfunc (s *Svc) Greet() {}\n\nfunc (s *Svc) Method() {\n" + body + "\n}\n"
+	file, err := parser.ParseFile(fset, "fixture.go", src, 0)
+	if err != nil {
+		t.Fatalf("parse fixture: %v", err)
+	}
+	for _, decl := range file.Decls {
+		if fd, ok := decl.(*ast.FuncDecl); ok && fd.Name.Name == "Method" {
+			return fd
+		}
+	}
+	t.Fatalf("fixture has no Method func decl")
+	return nil
+}
+
+// TestExtractFieldAccess_ExcludesMethodCalls covers a method calling a sibling
+// method on the same receiver (s.Greet()): that selector must not be reported
+// as a field read, since it's already captured in Calls.
+func TestExtractFieldAccess_ExcludesMethodCalls(t *testing.T) {
+	fd := methodFuncDecl(t, "s.Greet()")
+	reads, writes := extractFieldAccess(fd, "s")
+	if len(reads) != 0 {
+		t.Errorf("reads = %v, want none (Greet is a method call, not a field read)", reads)
+	}
+	if len(writes) != 0 {
+		t.Errorf("writes = %v, want none", writes)
+	}
+}
+
+func TestExtractFieldAccess_ReadAndWrite(t *testing.T) {
+	fd := methodFuncDecl(t, "_ = s.Name\ns.Name = \"x\"")
+	reads, writes := extractFieldAccess(fd, "s")
+	if len(reads) != 1 || reads[0] != "Name" {
+		t.Errorf("reads = %v, want [Name]", reads)
+	}
+	if len(writes) != 1 || writes[0] != "Name" {
+		t.Errorf("writes = %v, want [Name]", writes)
+	}
+}
+
+// TestParseGoPackage_CallSiteIsInterface covers the motivating fixture for
+// whole-package call resolution: a call through an interface-typed field
+// must be reported with IsInterface true.
+func TestParseGoPackage_CallSiteIsInterface(t *testing.T) {
+	dir := t.TempDir()
+	src := `package fixture
+
+type AccountRepository interface {
+	Save(id string) error
+}
+
+type repoImpl struct{}
+
+func (r *repoImpl) Save(id string) error { return nil }
+
+type AccountService struct {
+	repo AccountRepository
+}
+
+func (s *AccountService) CreateAccount(accountID string) error {
+	return s.repo.Save(accountID)
+}
+`
+	if err := os.WriteFile(dir+"/fixture.go", []byte(src), 0o644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	metadata, err := parseGoPackage(dir)
+	if err != nil {
+		t.Fatalf("parseGoPackage: %v", err)
+	}
+
+	var call *CallSite
+	for _, m := range metadata.Methods {
+		if m.Name != "CreateAccount" {
+			continue
+		}
+		for i := range m.Calls {
+			if m.Calls[i].Callee == "Save" {
+				call = &m.Calls[i]
+			}
+		}
+	}
+	if call == nil {
+		t.Fatalf("no call site found for repo.Save in CreateAccount; calls seen: %+v", metadata.Methods)
+	}
+	if !call.IsInterface {
+		t.Errorf("IsInterface = false, want true for a call through an interface-typed field")
+	}
+}